@@ -0,0 +1,119 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import "strings"
+
+// otelSpanKinds maps the OTLP SPAN_KIND_* enumeration names to Jaeger's
+// `span.kind` tag values.
+var otelSpanKinds = map[string]SpanKind{
+	"SPAN_KIND_INTERNAL": SpanKindInternal,
+	"SPAN_KIND_SERVER":   SpanKindServer,
+	"SPAN_KIND_CLIENT":   SpanKindClient,
+	"SPAN_KIND_PRODUCER": SpanKindProducer,
+	"SPAN_KIND_CONSUMER": SpanKindConsumer,
+}
+
+// jaegerToOTelSpanKinds is the inverse of otelSpanKinds.
+var jaegerToOTelSpanKinds = map[SpanKind]string{
+	SpanKindInternal: "SPAN_KIND_INTERNAL",
+	SpanKindServer:   "SPAN_KIND_SERVER",
+	SpanKindClient:   "SPAN_KIND_CLIENT",
+	SpanKindProducer: "SPAN_KIND_PRODUCER",
+	SpanKindConsumer: "SPAN_KIND_CONSUMER",
+}
+
+// otelSamplingProbabilityKey, otelSamplingRateLimitKey, and
+// otelSamplingDecisionKey are the attribute conventions OTLP
+// exporters/collectors use to carry the sampling decision made upstream,
+// since OTLP has no equivalent of Jaeger's sampler.type tag.
+const (
+	otelSamplingProbabilityKey = "sampling.probability"
+	otelSamplingRateLimitKey   = "sampling.rate_limit"
+	// otelSamplingDecisionKey carries a bare record/drop decision with no
+	// associated rate. It's normalized to SamplerTypeProbabilistic with Param
+	// 1 for a "kept" span and 0 for a "dropped" one: not the sampler that
+	// made the decision upstream, but a defensible reading of a binary
+	// decision as a degenerate probability, since OTLP carries no richer
+	// signal here. It is deliberately not mapped to SamplerTypeAdaptive,
+	// which specifically means the per-operation remote sampler with a
+	// probability/lower-bound/max-rate triple.
+	otelSamplingDecisionKey = "sampling.decision"
+)
+
+// SpanKindFromOTel converts an OTLP SPAN_KIND_* enum name to a Jaeger
+// SpanKind. It returns false if kind is not one of the five OTLP span kinds.
+func SpanKindFromOTel(kind string) (SpanKind, bool) {
+	k, ok := otelSpanKinds[strings.ToUpper(kind)]
+	return k, ok
+}
+
+// OTelSpanKind converts a Jaeger SpanKind to the corresponding OTLP
+// SPAN_KIND_* enum name. It returns "" if kind has no OTLP equivalent.
+func OTelSpanKind(kind SpanKind) string {
+	return jaegerToOTelSpanKinds[kind]
+}
+
+// SamplerTypeFromOTelAttributes inspects OTLP sampling.* attributes and
+// normalizes them to Jaeger's sampler.type/sampler.param model. It returns
+// SamplerTypeUnrecognized, 0 if kvs carry none of the recognized attributes.
+func SamplerTypeFromOTelAttributes(kvs []KeyValue) (SamplerType, float64) {
+	tags := KeyValues(kvs)
+	if tag, ok := tags.FindByKey(otelSamplingProbabilityKey); ok {
+		if p, err := tagToFloat(tag); err == nil {
+			return SamplerTypeProbabilistic, p
+		}
+	}
+	if tag, ok := tags.FindByKey(otelSamplingRateLimitKey); ok {
+		if p, err := tagToFloat(tag); err == nil {
+			return SamplerTypeRateLimiting, p
+		}
+	}
+	if tag, ok := tags.FindByKey(otelSamplingDecisionKey); ok {
+		switch strings.ToLower(tag.AsString()) {
+		case "record_and_sample", "true":
+			return SamplerTypeProbabilistic, 1
+		case "record_only", "false", "drop":
+			return SamplerTypeProbabilistic, 0
+		}
+	}
+	return SamplerTypeUnrecognized, 0
+}
+
+// ToOTelKind returns the OTLP SPAN_KIND_* enum name for the span's
+// `span.kind` tag, or "" if the span has no recognized span kind.
+func (s *Span) ToOTelKind() string {
+	kind, ok := s.GetSpanKind()
+	if !ok {
+		return ""
+	}
+	return OTelSpanKind(kind)
+}
+
+// SetSamplerFromOTel sets the span's sampler.type/sampler.param tags from
+// OTLP sampling.* attributes, so that storage ingesting OTLP spans doesn't
+// need to duplicate the attribute parsing in SamplerTypeFromOTelAttributes.
+// It is a no-op if kvs carry none of the recognized sampling attributes.
+func (s *Span) SetSamplerFromOTel(kvs []KeyValue) {
+	samplerType, param := SamplerTypeFromOTelAttributes(kvs)
+	if samplerType == SamplerTypeUnrecognized {
+		return
+	}
+	for _, tag := range []KeyValue{
+		String(SamplerTypeKey, samplerType.String()),
+		Float64(SamplerParamKey, param),
+	} {
+		replaced := false
+		for i := range s.Tags {
+			if s.Tags[i].Key == tag.Key {
+				s.Tags[i] = tag
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			s.Tags = append(s.Tags, tag)
+		}
+	}
+}
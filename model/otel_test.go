@@ -0,0 +1,140 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpanKindFromOTel(t *testing.T) {
+	tests := []struct {
+		otelKind string
+		expected SpanKind
+		ok       bool
+	}{
+		{"SPAN_KIND_INTERNAL", SpanKindInternal, true},
+		{"SPAN_KIND_SERVER", SpanKindServer, true},
+		{"SPAN_KIND_CLIENT", SpanKindClient, true},
+		{"SPAN_KIND_PRODUCER", SpanKindProducer, true},
+		{"SPAN_KIND_CONSUMER", SpanKindConsumer, true},
+		{"span_kind_server", SpanKindServer, true}, // case-insensitive
+		{"SPAN_KIND_UNSPECIFIED", SpanKindUnspecified, false},
+		{"bogus", SpanKindUnspecified, false},
+	}
+	for _, test := range tests {
+		kind, ok := SpanKindFromOTel(test.otelKind)
+		assert.Equal(t, test.ok, ok, test.otelKind)
+		if test.ok {
+			assert.Equal(t, test.expected, kind, test.otelKind)
+		}
+	}
+}
+
+func TestOTelSpanKind(t *testing.T) {
+	tests := []struct {
+		kind     SpanKind
+		expected string
+	}{
+		{SpanKindInternal, "SPAN_KIND_INTERNAL"},
+		{SpanKindServer, "SPAN_KIND_SERVER"},
+		{SpanKindClient, "SPAN_KIND_CLIENT"},
+		{SpanKindProducer, "SPAN_KIND_PRODUCER"},
+		{SpanKindConsumer, "SPAN_KIND_CONSUMER"},
+		{SpanKindUnspecified, ""},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, OTelSpanKind(test.kind))
+	}
+}
+
+func TestSpan_ToOTelKind(t *testing.T) {
+	span := &Span{Tags: KeyValues{SpanKindTag(SpanKindServer)}}
+	assert.Equal(t, "SPAN_KIND_SERVER", span.ToOTelKind())
+
+	span = &Span{}
+	assert.Equal(t, "", span.ToOTelKind())
+}
+
+func TestSamplerTypeFromOTelAttributes(t *testing.T) {
+	tests := []struct {
+		name          string
+		kvs           []KeyValue
+		expectedType  SamplerType
+		expectedParam float64
+	}{
+		{
+			name:          "probability",
+			kvs:           []KeyValue{Float64("sampling.probability", 0.5)},
+			expectedType:  SamplerTypeProbabilistic,
+			expectedParam: 0.5,
+		},
+		{
+			name:          "rate limit",
+			kvs:           []KeyValue{Int64("sampling.rate_limit", 10)},
+			expectedType:  SamplerTypeRateLimiting,
+			expectedParam: 10,
+		},
+		{
+			name:          "lowerbound and const have no OTel attribute convention",
+			kvs:           []KeyValue{String("sampler.type", "lowerbound")},
+			expectedType:  SamplerTypeUnrecognized,
+			expectedParam: 0,
+		},
+		{
+			name:          "unrecognized fallback",
+			kvs:           []KeyValue{String("unrelated", "tag")},
+			expectedType:  SamplerTypeUnrecognized,
+			expectedParam: 0,
+		},
+		{
+			name:          "sampling.decision record_and_sample normalizes to probability 1",
+			kvs:           []KeyValue{String("sampling.decision", "record_and_sample")},
+			expectedType:  SamplerTypeProbabilistic,
+			expectedParam: 1,
+		},
+		{
+			name:          "sampling.decision true normalizes to probability 1",
+			kvs:           []KeyValue{String("sampling.decision", "true")},
+			expectedType:  SamplerTypeProbabilistic,
+			expectedParam: 1,
+		},
+		{
+			name:          "sampling.decision drop normalizes to probability 0",
+			kvs:           []KeyValue{String("sampling.decision", "drop")},
+			expectedType:  SamplerTypeProbabilistic,
+			expectedParam: 0,
+		},
+		{
+			name:          "sampling.decision record_only normalizes to probability 0",
+			kvs:           []KeyValue{String("sampling.decision", "record_only")},
+			expectedType:  SamplerTypeProbabilistic,
+			expectedParam: 0,
+		},
+		{
+			name:          "unrecognized sampling.decision value falls back",
+			kvs:           []KeyValue{String("sampling.decision", "bogus")},
+			expectedType:  SamplerTypeUnrecognized,
+			expectedParam: 0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			samplerType, param := SamplerTypeFromOTelAttributes(test.kvs)
+			assert.Equal(t, test.expectedType, samplerType)
+			assert.Equal(t, test.expectedParam, param)
+		})
+	}
+}
+
+func TestSpan_SetSamplerFromOTel(t *testing.T) {
+	span := &Span{}
+	span.SetSamplerFromOTel([]KeyValue{Float64("sampling.probability", 0.75)})
+	assert.Equal(t, SamplerTypeProbabilistic, span.GetSamplerType())
+
+	span = &Span{}
+	span.SetSamplerFromOTel([]KeyValue{String("unrelated", "tag")})
+	assert.Empty(t, span.Tags)
+}
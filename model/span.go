@@ -5,8 +5,13 @@
 package model
 
 import (
-	"encoding/gob"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
 	"io"
+	"math"
+	"sort"
 	"strconv"
 
 	"go.uber.org/zap"
@@ -20,6 +25,10 @@ const (
 	SamplerTypeLowerBound
 	SamplerTypeRateLimiting
 	SamplerTypeConst
+	// SamplerTypeAdaptive identifies the remote/adaptive sampler, which makes
+	// its probabilistic/lowerbound decisions per operation rather than for
+	// the whole service.
+	SamplerTypeAdaptive
 
 	// SampledFlag is the bit set in Flags in order to define a span as a sampled span
 	SampledFlag = Flags(1)
@@ -27,6 +36,17 @@ const (
 	DebugFlag = Flags(2)
 	// FirehoseFlag is the bit in Flags in order to define a span as a firehose span
 	FirehoseFlag = Flags(8)
+	// RandomTraceIDFlag is the bit set in Flags to mark a trace ID as
+	// generated with the W3C Trace Context "random" property, i.e. its
+	// rightmost 7 bytes are suitable as a source of randomness.
+	RandomTraceIDFlag = Flags(4)
+
+	// SamplerLowerBoundKey is the tag key for the per-operation lower bound
+	// (in traces per second) enforced by an adaptive sampler.
+	SamplerLowerBoundKey = "sampler.lb"
+	// SamplerMaxTracesPerSecondKey is the tag key for the per-operation rate
+	// cap (in traces per second) enforced by an adaptive sampler.
+	SamplerMaxTracesPerSecondKey = "sampler.max"
 )
 
 // Flags is a bit map of flags for a span
@@ -38,6 +58,7 @@ var toSamplerType = map[string]SamplerType{
 	"lowerbound":    SamplerTypeLowerBound,
 	"ratelimiting":  SamplerTypeRateLimiting,
 	"const":         SamplerTypeConst,
+	"adaptive":      SamplerTypeAdaptive,
 }
 
 func (s SamplerType) String() string {
@@ -52,6 +73,8 @@ func (s SamplerType) String() string {
 		return "ratelimiting"
 	case SamplerTypeConst:
 		return "const"
+	case SamplerTypeAdaptive:
+		return "adaptive"
 	default:
 		return ""
 	}
@@ -62,11 +85,224 @@ func SpanKindTag(kind SpanKind) KeyValue {
 }
 
 // Hash implements Hash from Hashable.
+//
+// Deprecated: use Fingerprint instead. Hash now delegates to Fingerprint
+// under an FNV-128a digest so that hashes stay stable across process
+// restarts and Go versions, unlike the gob encoding this used to write
+// directly.
 func (s *Span) Hash(w io.Writer) (err error) {
-	// gob is not the most efficient way, but it ensures we don't miss any fields.
-	// See BenchmarkSpanHash in span_test.go
-	enc := gob.NewEncoder(w)
-	return enc.Encode(s)
+	h := fnv.New128a()
+	if err := s.Fingerprint(h); err != nil {
+		return err
+	}
+	_, err = w.Write(h.Sum(nil))
+	return err
+}
+
+// Fingerprint writes a deterministic, allocation-light canonical encoding of
+// the span to w: TraceID, SpanID, ParentSpanID, OperationName,
+// Process.ServiceName, StartTime, Duration, and Flags, followed by
+// References, Tags, and Logs. References are ordered by (RefType, TraceID,
+// SpanID). Each Tag and each Log's Fields are first encoded (key, type,
+// type-tagged value) and then ordered by the encoded bytes rather than just
+// by Key, so tags sharing a key but differing in type or value still sort
+// deterministically. Logs are encoded as (Timestamp, encoded Fields) and
+// likewise ordered by those encoded bytes, so same-timestamp logs fall back
+// to comparing their fields instead of leaving the tie unresolved. This full
+// tiebreaking is what guarantees two spans with identical content always
+// produce identical bytes regardless of slice order, including spans with
+// duplicate-key tags or same-timestamp logs.
+func (s *Span) Fingerprint(w io.Writer) error {
+	var buf [8]byte
+
+	if err := writeUint64(w, &buf, s.TraceID.High); err != nil {
+		return err
+	}
+	if err := writeUint64(w, &buf, s.TraceID.Low); err != nil {
+		return err
+	}
+	if err := writeUint64(w, &buf, uint64(s.SpanID)); err != nil {
+		return err
+	}
+	if err := writeUint64(w, &buf, uint64(s.ParentSpanID())); err != nil {
+		return err
+	}
+	if err := writeString(w, &buf, s.OperationName); err != nil {
+		return err
+	}
+	var serviceName string
+	if s.Process != nil {
+		serviceName = s.Process.ServiceName
+	}
+	if err := writeString(w, &buf, serviceName); err != nil {
+		return err
+	}
+	if err := writeUint64(w, &buf, uint64(s.StartTime.UnixNano())); err != nil {
+		return err
+	}
+	if err := writeUint64(w, &buf, uint64(s.Duration.Nanoseconds())); err != nil {
+		return err
+	}
+	if err := writeUint64(w, &buf, uint64(uint32(s.Flags))); err != nil {
+		return err
+	}
+
+	refs := append([]SpanRef(nil), s.References...)
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].RefType != refs[j].RefType {
+			return refs[i].RefType < refs[j].RefType
+		}
+		if refs[i].TraceID.High != refs[j].TraceID.High {
+			return refs[i].TraceID.High < refs[j].TraceID.High
+		}
+		if refs[i].TraceID.Low != refs[j].TraceID.Low {
+			return refs[i].TraceID.Low < refs[j].TraceID.Low
+		}
+		return refs[i].SpanID < refs[j].SpanID
+	})
+	if err := writeUint64(w, &buf, uint64(len(refs))); err != nil {
+		return err
+	}
+	for _, ref := range refs {
+		if err := writeUint64(w, &buf, uint64(ref.RefType)); err != nil {
+			return err
+		}
+		if err := writeUint64(w, &buf, ref.TraceID.High); err != nil {
+			return err
+		}
+		if err := writeUint64(w, &buf, ref.TraceID.Low); err != nil {
+			return err
+		}
+		if err := writeUint64(w, &buf, uint64(ref.SpanID)); err != nil {
+			return err
+		}
+	}
+
+	encodedTags, err := encodeSortedTags(s.Tags)
+	if err != nil {
+		return err
+	}
+	if err := writeUint64(w, &buf, uint64(len(encodedTags))); err != nil {
+		return err
+	}
+	for _, tag := range encodedTags {
+		if _, err := w.Write(tag); err != nil {
+			return err
+		}
+	}
+
+	encodedLogs := make([][]byte, len(s.Logs))
+	for i, log := range s.Logs {
+		var logBuf bytes.Buffer
+		if err := writeUint64(&logBuf, &buf, uint64(log.Timestamp.UnixNano())); err != nil {
+			return err
+		}
+		encodedFields, err := encodeSortedTags(log.Fields)
+		if err != nil {
+			return err
+		}
+		if err := writeUint64(&logBuf, &buf, uint64(len(encodedFields))); err != nil {
+			return err
+		}
+		for _, field := range encodedFields {
+			if _, err := logBuf.Write(field); err != nil {
+				return err
+			}
+		}
+		encodedLogs[i] = logBuf.Bytes()
+	}
+	sort.Slice(encodedLogs, func(i, j int) bool { return bytes.Compare(encodedLogs[i], encodedLogs[j]) < 0 })
+	if err := writeUint64(w, &buf, uint64(len(encodedLogs))); err != nil {
+		return err
+	}
+	for _, enc := range encodedLogs {
+		if _, err := w.Write(enc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeUint64 writes v to w as 8 big-endian bytes, using scratch as
+// temporary storage to avoid an allocation per call.
+func writeUint64(w io.Writer, scratch *[8]byte, v uint64) error {
+	binary.BigEndian.PutUint64(scratch[:], v)
+	_, err := w.Write(scratch[:])
+	return err
+}
+
+// writeString writes v to w as a length prefix followed by its bytes.
+func writeString(w io.Writer, scratch *[8]byte, v string) error {
+	if err := writeUint64(w, scratch, uint64(len(v))); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, v)
+	return err
+}
+
+// encodeTag returns the canonical encoding of a single tag: its key, type,
+// and type-tagged value.
+func encodeTag(t KeyValue) ([]byte, error) {
+	var buf bytes.Buffer
+	var scratch [8]byte
+	if err := writeString(&buf, &scratch, t.Key); err != nil {
+		return nil, err
+	}
+	if err := writeUint64(&buf, &scratch, uint64(t.VType)); err != nil {
+		return nil, err
+	}
+	switch t.VType {
+	case StringType:
+		if err := writeString(&buf, &scratch, t.VStr); err != nil {
+			return nil, err
+		}
+	case BoolType:
+		v := uint64(0)
+		if t.VBool {
+			v = 1
+		}
+		if err := writeUint64(&buf, &scratch, v); err != nil {
+			return nil, err
+		}
+	case Int64Type:
+		if err := writeUint64(&buf, &scratch, uint64(t.VInt64)); err != nil {
+			return nil, err
+		}
+	case Float64Type:
+		if err := writeUint64(&buf, &scratch, math.Float64bits(t.VFloat64)); err != nil {
+			return nil, err
+		}
+	case BinaryType:
+		if err := writeUint64(&buf, &scratch, uint64(len(t.VBinary))); err != nil {
+			return nil, err
+		}
+		if _, err := buf.Write(t.VBinary); err != nil {
+			return nil, err
+		}
+	default:
+		if err := writeString(&buf, &scratch, t.AsString()); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// encodeSortedTags returns the canonical encoding of each tag in tags,
+// ordered by the full encoded bytes rather than just by Key, so that tags
+// sharing a key but differing in type or value still sort deterministically
+// regardless of the order they arrived in.
+func encodeSortedTags(tags []KeyValue) ([][]byte, error) {
+	encoded := make([][]byte, len(tags))
+	for i, tag := range tags {
+		enc, err := encodeTag(tag)
+		if err != nil {
+			return nil, err
+		}
+		encoded[i] = enc
+	}
+	sort.Slice(encoded, func(i, j int) bool { return bytes.Compare(encoded[i], encoded[j]) < 0 })
+	return encoded, nil
 }
 
 // HasSpanKind returns true if the span has a `span.kind` tag set to `kind`.
@@ -153,25 +389,67 @@ func (s *Span) ReplaceParentID(newParentID SpanID) {
 	s.References = MaybeAddParentSpanID(s.TraceID, newParentID, s.References)
 }
 
-// GetSamplerParams returns the sampler.type and sampler.param value if they are valid.
+// SamplerParams holds the composite parameters describing how a span's
+// sampling decision was made. Type and Param are always populated for a
+// recognized sampler; LowerBoundTracesPerSecond and MaxTracesPerSecond are
+// left at zero when the span carries no sampler.lb / sampler.max tag, which
+// is the common case for samplers other than adaptive.
+type SamplerParams struct {
+	// Type is the sampler type, e.g. probabilistic, ratelimiting, adaptive.
+	Type SamplerType
+	// Param is the sampler.param value (probability, rate, etc. depending on Type).
+	Param float64
+	// LowerBoundTracesPerSecond is the per-operation lower bound enforced by
+	// an adaptive sampler, read from the sampler.lb tag.
+	LowerBoundTracesPerSecond float64
+	// OperationName is the operation the sampling decision was scoped to.
+	OperationName string
+	// MaxTracesPerSecond is the per-operation rate cap enforced by an
+	// adaptive sampler, read from the sampler.max tag.
+	MaxTracesPerSecond float64
+}
+
+// GetSamplerParams returns the sampler.type and sampler.param value if they
+// are valid.
+//
+// Deprecated: use GetSamplerConfig, which also returns the per-operation
+// adaptive sampling fields (sampler.lb, sampler.max, OperationName).
 func (s *Span) GetSamplerParams(logger *zap.Logger) (SamplerType, float64) {
+	config := s.GetSamplerConfig(logger)
+	return config.Type, config.Param
+}
+
+// GetSamplerConfig returns the composite sampler parameters for the span,
+// including the sampler.lb/sampler.max fields used by the adaptive sampler.
+// The zero value (Type == SamplerTypeUnrecognized) is returned if
+// sampler.type is not set, sampler.param is missing, or the sampler tags
+// cannot be parsed — callers gate on that sentinel to mean "no usable
+// sampling info", same as before per-operation sampling existed.
+func (s *Span) GetSamplerConfig(logger *zap.Logger) SamplerParams {
 	samplerType := s.GetSamplerType()
 	if samplerType == SamplerTypeUnrecognized {
-		return SamplerTypeUnrecognized, 0
-	}
-	tag, ok := KeyValues(s.Tags).FindByKey(SamplerParamKey)
-	if !ok {
-		return SamplerTypeUnrecognized, 0
+		return SamplerParams{}
 	}
-	samplerParam, err := samplerParamToFloat(tag)
+	params, hasParam, err := parseSamplerParams(KeyValues(s.Tags))
 	if err != nil {
 		logger.
 			With(zap.String("traceID", s.TraceID.String())).
 			With(zap.String("spanID", s.SpanID.String())).
-			Warn("sampler.param tag is not a number", zap.Any("tag", tag))
-		return SamplerTypeUnrecognized, 0
+			Warn("sampler tags are not valid", zap.Error(err))
+		return SamplerParams{}
+	}
+	if !hasParam {
+		return SamplerParams{}
 	}
-	return samplerType, samplerParam
+	params.Type = samplerType
+	params.OperationName = s.OperationName
+	return params
+}
+
+// IsAdaptivelySampled returns true if the span's sampling decision was made
+// by the adaptive (per-operation, remote) sampler.
+func (s *Span) IsAdaptivelySampled() bool {
+	return s.GetSamplerType() == SamplerTypeAdaptive
 }
 
 // ------- Flags -------
@@ -191,6 +469,11 @@ func (f *Flags) SetFirehose() {
 	f.setFlags(FirehoseFlag)
 }
 
+// SetRandom sets the Flags as carrying a W3C "random" trace ID.
+func (f *Flags) SetRandom() {
+	f.setFlags(RandomTraceIDFlag)
+}
+
 func (f *Flags) setFlags(bit Flags) {
 	*f |= bit
 }
@@ -212,18 +495,126 @@ func (f Flags) IsFirehoseEnabled() bool {
 	return f.checkFlags(FirehoseFlag)
 }
 
+// IsRandom returns true if the Flags denote a W3C "random" trace ID.
+func (f Flags) IsRandom() bool {
+	return f.checkFlags(RandomTraceIDFlag)
+}
+
 func (f Flags) checkFlags(bit Flags) bool {
 	return f&bit == bit
 }
 
-func samplerParamToFloat(samplerParamTag KeyValue) (float64, error) {
-	// The param could be represented as a string, an int, or a float
-	switch samplerParamTag.VType {
+// w3cSampledBit and w3cRandomBit are the bit positions of the sampled and
+// random properties within the single-byte W3C trace-flags field, per
+// https://www.w3.org/TR/trace-context/#trace-flags and the updated draft
+// that adds the "random" property.
+const (
+	w3cSampledBit byte = 0x1
+	w3cRandomBit  byte = 0x2
+)
+
+// ToW3C converts f to a W3C Trace Context trace-flags byte, carrying over
+// the sampled and random properties. DebugFlag and FirehoseFlag have no W3C
+// equivalent; use MarshalW3CTraceState to carry those.
+func (f Flags) ToW3C() byte {
+	var b byte
+	if f.IsSampled() {
+		b |= w3cSampledBit
+	}
+	if f.IsRandom() {
+		b |= w3cRandomBit
+	}
+	return b
+}
+
+// FlagsFromW3C converts a W3C Trace Context trace-flags byte to Flags,
+// setting SampledFlag and RandomTraceIDFlag as indicated by b.
+func FlagsFromW3C(b byte) Flags {
+	var f Flags
+	if b&w3cSampledBit != 0 {
+		f.SetSampled()
+	}
+	if b&w3cRandomBit != 0 {
+		f.SetRandom()
+	}
+	return f
+}
+
+// MarshalW3CTraceState encodes the DebugFlag and FirehoseFlag bits of f as
+// the value of a Jaeger-specific W3C tracestate list-member (see
+// https://www.w3.org/TR/trace-context/#tracestate-header), so that the
+// jaeger-debug-id and firehose signals survive a round trip through an OTLP
+// pipeline that only understands the standard trace-flags byte.
+func (f Flags) MarshalW3CTraceState() string {
+	var b byte
+	if f.IsDebug() {
+		b |= 0x1
+	}
+	if f.IsFirehoseEnabled() {
+		b |= 0x2
+	}
+	return strconv.FormatUint(uint64(b), 16)
+}
+
+// UnmarshalW3CTraceState parses a value produced by MarshalW3CTraceState and
+// returns the DebugFlag/FirehoseFlag bits it encodes.
+func UnmarshalW3CTraceState(v string) (Flags, error) {
+	b, err := strconv.ParseUint(v, 16, 8)
+	if err != nil {
+		return 0, fmt.Errorf("invalid jaeger tracestate value %q: %w", v, err)
+	}
+	var f Flags
+	if b&0x1 != 0 {
+		f.SetDebug()
+	}
+	if b&0x2 != 0 {
+		f.SetFirehose()
+	}
+	return f, nil
+}
+
+// parseSamplerParams reads the sampler.param, sampler.lb, and sampler.max
+// tags and returns them as a composite SamplerParams. sampler.param is
+// required; its absence is reported via the second return value so callers
+// can treat it the same as an unrecognized sampler. sampler.lb and
+// sampler.max are optional and left at zero when the span doesn't carry
+// them.
+func parseSamplerParams(tags KeyValues) (params SamplerParams, hasParam bool, err error) {
+	paramTag, ok := tags.FindByKey(SamplerParamKey)
+	if !ok {
+		return SamplerParams{}, false, nil
+	}
+	param, err := tagToFloat(paramTag)
+	if err != nil {
+		return SamplerParams{}, false, err
+	}
+	params.Param = param
+	if lbTag, ok := tags.FindByKey(SamplerLowerBoundKey); ok {
+		lb, err := tagToFloat(lbTag)
+		if err != nil {
+			return SamplerParams{}, false, err
+		}
+		params.LowerBoundTracesPerSecond = lb
+	}
+	if maxTag, ok := tags.FindByKey(SamplerMaxTracesPerSecondKey); ok {
+		max, err := tagToFloat(maxTag)
+		if err != nil {
+			return SamplerParams{}, false, err
+		}
+		params.MaxTracesPerSecond = max
+	}
+	return params, true, nil
+}
+
+// tagToFloat extracts a float64 value from a tag that could be represented
+// as a string, an int, or a float.
+func tagToFloat(tag KeyValue) (float64, error) {
+	switch tag.VType {
 	case Float64Type:
-		return samplerParamTag.Float64(), nil
+		return tag.Float64(), nil
 	case Int64Type:
-		return float64(samplerParamTag.Int64()), nil
+		return float64(tag.Int64()), nil
 	default:
-		return strconv.ParseFloat(samplerParamTag.AsString(), 64)
+		return strconv.ParseFloat(tag.AsString(), 64)
 	}
 }
@@ -0,0 +1,337 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"bytes"
+	"encoding/gob"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSamplerType_String(t *testing.T) {
+	tests := []struct {
+		samplerType SamplerType
+		expected    string
+	}{
+		{SamplerTypeUnrecognized, "unrecognized"},
+		{SamplerTypeProbabilistic, "probabilistic"},
+		{SamplerTypeLowerBound, "lowerbound"},
+		{SamplerTypeRateLimiting, "ratelimiting"},
+		{SamplerTypeConst, "const"},
+		{SamplerTypeAdaptive, "adaptive"},
+		{SamplerType(-1), ""},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, test.samplerType.String())
+	}
+}
+
+func TestToSamplerType(t *testing.T) {
+	for str, expected := range toSamplerType {
+		assert.Equal(t, expected, toSamplerType[str])
+	}
+	assert.Equal(t, SamplerTypeAdaptive, toSamplerType["adaptive"])
+}
+
+func makeSamplerSpan(tags KeyValues) *Span {
+	return &Span{
+		TraceID: NewTraceID(0, 1),
+		SpanID:  NewSpanID(1),
+		Tags:    tags,
+	}
+}
+
+func TestSpan_GetSamplerConfig_MixedTagTypes(t *testing.T) {
+	logger := zap.NewNop()
+
+	tests := []struct {
+		name     string
+		tags     KeyValues
+		expected SamplerParams
+	}{
+		{
+			name: "string-encoded param",
+			tags: KeyValues{
+				String(SamplerTypeKey, "probabilistic"),
+				String(SamplerParamKey, "0.5"),
+			},
+			expected: SamplerParams{Type: SamplerTypeProbabilistic, Param: 0.5},
+		},
+		{
+			name: "int-encoded param",
+			tags: KeyValues{
+				String(SamplerTypeKey, "ratelimiting"),
+				Int64(SamplerParamKey, 10),
+			},
+			expected: SamplerParams{Type: SamplerTypeRateLimiting, Param: 10},
+		},
+		{
+			name: "float-encoded param with adaptive lower bound and max",
+			tags: KeyValues{
+				String(SamplerTypeKey, "adaptive"),
+				Float64(SamplerParamKey, 0.25),
+				Float64(SamplerLowerBoundKey, 0.001),
+				Float64(SamplerMaxTracesPerSecondKey, 100),
+			},
+			expected: SamplerParams{
+				Type:                      SamplerTypeAdaptive,
+				Param:                     0.25,
+				LowerBoundTracesPerSecond: 0.001,
+				MaxTracesPerSecond:        100,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			span := makeSamplerSpan(test.tags)
+			test.expected.OperationName = span.OperationName
+			assert.Equal(t, test.expected, span.GetSamplerConfig(logger))
+		})
+	}
+}
+
+func TestSpan_GetSamplerConfig_MissingTags(t *testing.T) {
+	logger := zap.NewNop()
+
+	tests := []struct {
+		name string
+		tags KeyValues
+	}{
+		{
+			name: "no sampler tags at all",
+			tags: KeyValues{},
+		},
+		{
+			name: "sampler.type but no sampler.param",
+			tags: KeyValues{
+				String(SamplerTypeKey, "probabilistic"),
+			},
+		},
+		{
+			name: "unrecognized sampler.type",
+			tags: KeyValues{
+				String(SamplerTypeKey, "bogus"),
+				Float64(SamplerParamKey, 0.5),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			span := makeSamplerSpan(test.tags)
+			assert.Equal(t, SamplerParams{}, span.GetSamplerConfig(logger))
+			assert.False(t, span.IsAdaptivelySampled())
+		})
+	}
+}
+
+func TestSpan_GetSamplerParams_Deprecated(t *testing.T) {
+	logger := zap.NewNop()
+	span := makeSamplerSpan(KeyValues{
+		String(SamplerTypeKey, "probabilistic"),
+		Float64(SamplerParamKey, 0.5),
+	})
+	samplerType, param := span.GetSamplerParams(logger)
+	assert.Equal(t, SamplerTypeProbabilistic, samplerType)
+	assert.Equal(t, 0.5, param)
+
+	// sampler.param missing must still fall back to the unrecognized sentinel,
+	// matching pre-adaptive-sampling behavior that callers rely on.
+	span = makeSamplerSpan(KeyValues{
+		String(SamplerTypeKey, "probabilistic"),
+	})
+	samplerType, param = span.GetSamplerParams(logger)
+	assert.Equal(t, SamplerTypeUnrecognized, samplerType)
+	assert.Equal(t, float64(0), param)
+}
+
+func TestSpan_IsAdaptivelySampled(t *testing.T) {
+	span := makeSamplerSpan(KeyValues{
+		String(SamplerTypeKey, "adaptive"),
+		Float64(SamplerParamKey, 0.1),
+	})
+	assert.True(t, span.IsAdaptivelySampled())
+}
+
+func makeFingerprintSpan() *Span {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	return &Span{
+		TraceID:       NewTraceID(1, 2),
+		SpanID:        NewSpanID(3),
+		OperationName: "op",
+		References: []SpanRef{
+			{TraceID: NewTraceID(1, 2), SpanID: NewSpanID(4), RefType: ChildOf},
+			{TraceID: NewTraceID(1, 2), SpanID: NewSpanID(5), RefType: FollowsFrom},
+		},
+		Flags:     SampledFlag,
+		StartTime: start,
+		Duration:  42 * time.Millisecond,
+		Tags: KeyValues{
+			String("b", "2"),
+			Int64("a", 1),
+			Bool("c", true),
+			Float64("d", 1.5),
+		},
+		Logs: []Log{
+			{Timestamp: start.Add(2 * time.Millisecond), Fields: KeyValues{String("y", "2")}},
+			{Timestamp: start.Add(time.Millisecond), Fields: KeyValues{String("x", "1")}},
+		},
+		Process: &Process{ServiceName: "svc"},
+	}
+}
+
+func TestSpan_Fingerprint_SortStability(t *testing.T) {
+	reordered := makeFingerprintSpan()
+	reordered.References[0], reordered.References[1] = reordered.References[1], reordered.References[0]
+	reordered.Tags[0], reordered.Tags[1] = reordered.Tags[1], reordered.Tags[0]
+	reordered.Logs[0], reordered.Logs[1] = reordered.Logs[1], reordered.Logs[0]
+
+	var buf1, buf2 bytes.Buffer
+	require.NoError(t, makeFingerprintSpan().Fingerprint(&buf1))
+	require.NoError(t, reordered.Fingerprint(&buf2))
+	assert.Equal(t, buf1.Bytes(), buf2.Bytes())
+}
+
+func TestSpan_Fingerprint_TiebreaksDuplicateKeysAndTimestamps(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	makeSpan := func(tagOrder, logFieldOrder [2]string) *Span {
+		return &Span{
+			TraceID: NewTraceID(1, 2),
+			SpanID:  NewSpanID(3),
+			Tags: KeyValues{
+				String("dup", tagOrder[0]),
+				String("dup", tagOrder[1]),
+			},
+			Logs: []Log{
+				{Timestamp: start, Fields: KeyValues{String("f", logFieldOrder[0])}},
+				{Timestamp: start, Fields: KeyValues{String("f", logFieldOrder[1])}},
+			},
+		}
+	}
+
+	span := makeSpan([2]string{"a", "b"}, [2]string{"x", "y"})
+	reordered := makeSpan([2]string{"b", "a"}, [2]string{"y", "x"})
+
+	var buf1, buf2 bytes.Buffer
+	require.NoError(t, span.Fingerprint(&buf1))
+	require.NoError(t, reordered.Fingerprint(&buf2))
+	assert.Equal(t, buf1.Bytes(), buf2.Bytes())
+
+	// A genuinely different duplicate-key tag value must still change the output.
+	different := makeSpan([2]string{"a", "c"}, [2]string{"x", "y"})
+	var buf3 bytes.Buffer
+	require.NoError(t, different.Fingerprint(&buf3))
+	assert.NotEqual(t, buf1.Bytes(), buf3.Bytes())
+}
+
+func TestSpan_Fingerprint_DetectsDifference(t *testing.T) {
+	span := makeFingerprintSpan()
+	other := makeFingerprintSpan()
+	other.OperationName = "other-op"
+
+	var buf1, buf2 bytes.Buffer
+	require.NoError(t, span.Fingerprint(&buf1))
+	require.NoError(t, other.Fingerprint(&buf2))
+	assert.NotEqual(t, buf1.Bytes(), buf2.Bytes())
+}
+
+func TestSpan_Hash_StableAcrossCalls(t *testing.T) {
+	span := makeFingerprintSpan()
+	var buf1, buf2 bytes.Buffer
+	require.NoError(t, span.Hash(&buf1))
+	require.NoError(t, span.Hash(&buf2))
+	assert.Equal(t, buf1.Bytes(), buf2.Bytes())
+	assert.NotEmpty(t, buf1.Bytes())
+}
+
+func BenchmarkSpanFingerprint(b *testing.B) {
+	span := makeFingerprintSpan()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = span.Fingerprint(io.Discard)
+	}
+}
+
+func BenchmarkSpanHash(b *testing.B) {
+	span := makeFingerprintSpan()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = span.Hash(io.Discard)
+	}
+}
+
+// BenchmarkSpanHashGob reproduces the old gob-based Hash implementation for
+// comparison; gob was replaced because it is allocation-heavy and its wire
+// format is not guaranteed stable across Go versions.
+func BenchmarkSpanHashGob(b *testing.B) {
+	span := makeFingerprintSpan()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		enc := gob.NewEncoder(io.Discard)
+		_ = enc.Encode(span)
+	}
+}
+
+func TestFlags_W3CRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		sampled  bool
+		random   bool
+		debug    bool
+		firehose bool
+	}{
+		{name: "none"},
+		{name: "sampled", sampled: true},
+		{name: "random", random: true},
+		{name: "sampled+random", sampled: true, random: true},
+		{name: "debug", debug: true},
+		{name: "firehose", firehose: true},
+		{name: "debug+firehose", debug: true, firehose: true},
+		{name: "all", sampled: true, random: true, debug: true, firehose: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var f Flags
+			if test.sampled {
+				f.SetSampled()
+			}
+			if test.random {
+				f.SetRandom()
+			}
+			if test.debug {
+				f.SetDebug()
+			}
+			if test.firehose {
+				f.SetFirehose()
+			}
+
+			w3c := f.ToW3C()
+			roundTripped := FlagsFromW3C(w3c)
+			assert.Equal(t, test.sampled, roundTripped.IsSampled())
+			assert.Equal(t, test.random, roundTripped.IsRandom())
+			// ToW3C/FlagsFromW3C only carry the sampled/random bits.
+			assert.False(t, roundTripped.IsDebug())
+			assert.False(t, roundTripped.IsFirehoseEnabled())
+
+			state := f.MarshalW3CTraceState()
+			parsed, err := UnmarshalW3CTraceState(state)
+			require.NoError(t, err)
+			assert.Equal(t, test.debug, parsed.IsDebug())
+			assert.Equal(t, test.firehose, parsed.IsFirehoseEnabled())
+		})
+	}
+}
+
+func TestUnmarshalW3CTraceState_Invalid(t *testing.T) {
+	_, err := UnmarshalW3CTraceState("not-hex!")
+	assert.Error(t, err)
+}